@@ -0,0 +1,14 @@
+// Package broker описывает интерфейс публикации событий в брокер сообщений
+// (Kafka, NATS, ...), чтобы сервис посылок мог быть источником событий для
+// внешних потребителей (уведомления, аналитика) без опроса базы данных.
+package broker
+
+// Handler - обработчик сообщений, полученных через Subscribe.
+type Handler func(msg []byte) error
+
+// Broker - интерфейс публикации и подписки на сообщения, не привязанный
+// к конкретной реализации (Kafka, NATS, ...).
+type Broker interface {
+	Publish(topic string, msg []byte) error
+	Subscribe(topic string, h Handler) error
+}
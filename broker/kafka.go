@@ -0,0 +1,109 @@
+package broker
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/Shopify/sarama"
+)
+
+// KafkaConfig - параметры подключения к Kafka, читаемые из окружения
+// (KafkaConfigFromEnv); остальная конфигурация сервиса задается флагами
+// командной строки (см. main.go).
+type KafkaConfig struct {
+	Brokers       []string // Адреса брокеров (KAFKA_HOST, через запятую).
+	ConsumerGroup string   // Идентификатор группы потребителей (KAFKA_CONSUMER_ID).
+	TopicPrefix   string   // Префикс топиков, например "parcel" -> "parcel.registered".
+}
+
+// KafkaConfigFromEnv читает KafkaConfig из переменных окружения:
+// KAFKA_HOST, KAFKA_CONSUMER_ID и необязательную KAFKA_TOPIC_PREFIX.
+func KafkaConfigFromEnv() KafkaConfig {
+	prefix := os.Getenv("KAFKA_TOPIC_PREFIX")
+	if prefix == "" {
+		prefix = "parcel"
+	}
+
+	return KafkaConfig{
+		Brokers:       strings.Split(os.Getenv("KAFKA_HOST"), ","),
+		ConsumerGroup: os.Getenv("KAFKA_CONSUMER_ID"),
+		TopicPrefix:   prefix,
+	}
+}
+
+// KafkaBroker - реализация Broker поверх github.com/Shopify/sarama.
+type KafkaBroker struct {
+	cfg      KafkaConfig
+	producer sarama.SyncProducer
+}
+
+// NewKafkaBroker поднимает синхронного sarama-продюсера для заданной
+// конфигурации. Consumer-группа здесь не создается: каждый вызов Subscribe
+// создает свою собственную.
+func NewKafkaBroker(cfg KafkaConfig) (*KafkaBroker, error) {
+	saramaCfg := sarama.NewConfig()
+	saramaCfg.Producer.Return.Successes = true
+
+	producer, err := sarama.NewSyncProducer(cfg.Brokers, saramaCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KafkaBroker{cfg: cfg, producer: producer}, nil
+}
+
+// Publish публикует сообщение в топик с учетом TopicPrefix.
+func (b *KafkaBroker) Publish(topic string, msg []byte) error {
+	_, _, err := b.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: b.cfg.TopicPrefix + "." + topic,
+		Value: sarama.ByteEncoder(msg),
+	})
+
+	return err
+}
+
+// Subscribe запускает consumer group сервиса на указанном топике и передает
+// каждое полученное сообщение в h. Блокирует вызывающую горутину, поэтому
+// обычно запускается через `go broker.Subscribe(...)`.
+func (b *KafkaBroker) Subscribe(topic string, h Handler) error {
+	group, err := sarama.NewConsumerGroup(b.cfg.Brokers, b.cfg.ConsumerGroup, sarama.NewConfig())
+	if err != nil {
+		return err
+	}
+
+	consumer := kafkaConsumerGroupHandler{handler: h}
+	fullTopic := b.cfg.TopicPrefix + "." + topic
+
+	ctx := context.Background()
+
+	for {
+		if err := group.Consume(ctx, []string{fullTopic}, consumer); err != nil {
+			return err
+		}
+	}
+}
+
+// Close освобождает продюсера.
+func (b *KafkaBroker) Close() error {
+	return b.producer.Close()
+}
+
+// kafkaConsumerGroupHandler адаптирует Handler к sarama.ConsumerGroupHandler.
+type kafkaConsumerGroupHandler struct {
+	handler Handler
+}
+
+func (kafkaConsumerGroupHandler) Setup(sarama.ConsumerGroupSession) error   { return nil }
+func (kafkaConsumerGroupHandler) Cleanup(sarama.ConsumerGroupSession) error { return nil }
+
+func (h kafkaConsumerGroupHandler) ConsumeClaim(sess sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for msg := range claim.Messages() {
+		if err := h.handler(msg.Value); err != nil {
+			return err
+		}
+		sess.MarkMessage(msg, "")
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package broker
+
+// NoopBroker - реализация Broker по умолчанию, ничего не публикующая.
+// Используется, когда адрес брокера не сконфигурирован, чтобы сервис
+// мог работать без внешних зависимостей.
+type NoopBroker struct{}
+
+// NewNoopBroker - конструктор для NoopBroker.
+func NewNoopBroker() NoopBroker {
+	return NoopBroker{}
+}
+
+// Publish ничего не делает и всегда возвращает nil.
+func (NoopBroker) Publish(topic string, msg []byte) error {
+	return nil
+}
+
+// Subscribe ничего не делает и всегда возвращает nil.
+func (NoopBroker) Subscribe(topic string, h Handler) error {
+	return nil
+}
@@ -0,0 +1,205 @@
+// Package endpoint описывает слой go-kit endpoint'ов: по одному на каждую
+// операцию сервиса, с request/response-структурами транспортного уровня.
+package endpoint
+
+import (
+	"context"
+
+	kitendpoint "github.com/go-kit/kit/endpoint"
+
+	"github.com/ClownDUmpling/ParcelTrackerService/service"
+	"github.com/ClownDUmpling/ParcelTrackerService/store"
+)
+
+// Endpoints собирает все endpoint'ы сервиса посылок в одном месте,
+// чтобы транспортный слой мог их единообразно оборачивать middleware'ами.
+type Endpoints struct {
+	RegisterEndpoint      kitendpoint.Endpoint
+	GetEndpoint           kitendpoint.Endpoint
+	GetByClientEndpoint   kitendpoint.Endpoint
+	ChangeAddressEndpoint kitendpoint.Endpoint
+	NextStatusEndpoint    kitendpoint.Endpoint
+	CancelEndpoint        kitendpoint.Endpoint
+	DeleteEndpoint        kitendpoint.Endpoint
+	HistoryEndpoint       kitendpoint.Endpoint
+}
+
+// MakeServerEndpoints строит набор Endpoints поверх переданного service.Service.
+func MakeServerEndpoints(s service.Service) Endpoints {
+	return Endpoints{
+		RegisterEndpoint:      makeRegisterEndpoint(s),
+		GetEndpoint:           makeGetEndpoint(s),
+		GetByClientEndpoint:   makeGetByClientEndpoint(s),
+		ChangeAddressEndpoint: makeChangeAddressEndpoint(s),
+		NextStatusEndpoint:    makeNextStatusEndpoint(s),
+		CancelEndpoint:        makeCancelEndpoint(s),
+		DeleteEndpoint:        makeDeleteEndpoint(s),
+		HistoryEndpoint:       makeHistoryEndpoint(s),
+	}
+}
+
+// RegisterRequest - запрос на регистрацию новой посылки.
+type RegisterRequest struct {
+	Client  int    `json:"client"`
+	Address string `json:"address"`
+}
+
+// RegisterResponse - ответ с зарегистрированной посылкой.
+type RegisterResponse struct {
+	Parcel store.Parcel `json:"parcel"`
+	Err    error        `json:"error,omitempty"`
+}
+
+func (r RegisterResponse) Failed() error { return r.Err }
+
+func makeRegisterEndpoint(s service.Service) kitendpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(RegisterRequest)
+		p, err := s.Register(ctx, req.Client, req.Address)
+		return RegisterResponse{Parcel: p, Err: err}, nil
+	}
+}
+
+// GetRequest - запрос на получение посылки по номеру.
+type GetRequest struct {
+	Number int `json:"number"`
+}
+
+// GetResponse - ответ с найденной посылкой.
+type GetResponse struct {
+	Parcel store.Parcel `json:"parcel"`
+	Err    error        `json:"error,omitempty"`
+}
+
+func (r GetResponse) Failed() error { return r.Err }
+
+func makeGetEndpoint(s service.Service) kitendpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetRequest)
+		p, err := s.Get(ctx, req.Number)
+		return GetResponse{Parcel: p, Err: err}, nil
+	}
+}
+
+// GetByClientRequest - запрос на получение всех посылок клиента.
+type GetByClientRequest struct {
+	Client int `json:"client"`
+}
+
+// GetByClientResponse - ответ со списком посылок клиента.
+type GetByClientResponse struct {
+	Parcels []store.Parcel `json:"parcels"`
+	Err     error          `json:"error,omitempty"`
+}
+
+func (r GetByClientResponse) Failed() error { return r.Err }
+
+func makeGetByClientEndpoint(s service.Service) kitendpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetByClientRequest)
+		parcels, err := s.GetByClient(ctx, req.Client)
+		return GetByClientResponse{Parcels: parcels, Err: err}, nil
+	}
+}
+
+// ChangeAddressRequest - запрос на смену адреса доставки.
+type ChangeAddressRequest struct {
+	Number  int    `json:"number"`
+	Address string `json:"address"`
+}
+
+// ChangeAddressResponse - ответ на смену адреса.
+type ChangeAddressResponse struct {
+	Err error `json:"error,omitempty"`
+}
+
+func (r ChangeAddressResponse) Failed() error { return r.Err }
+
+func makeChangeAddressEndpoint(s service.Service) kitendpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ChangeAddressRequest)
+		_, err := s.ChangeAddress(ctx, req.Number, req.Address)
+		return ChangeAddressResponse{Err: err}, nil
+	}
+}
+
+// NextStatusRequest - запрос на перевод посылки в следующий статус.
+type NextStatusRequest struct {
+	Number int `json:"number"`
+}
+
+// NextStatusResponse - ответ на перевод статуса.
+type NextStatusResponse struct {
+	Err error `json:"error,omitempty"`
+}
+
+func (r NextStatusResponse) Failed() error { return r.Err }
+
+func makeNextStatusEndpoint(s service.Service) kitendpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(NextStatusRequest)
+		err := s.NextStatus(ctx, req.Number)
+		return NextStatusResponse{Err: err}, nil
+	}
+}
+
+// CancelRequest - запрос на отмену посылки.
+type CancelRequest struct {
+	Number int `json:"number"`
+}
+
+// CancelResponse - ответ на отмену посылки.
+type CancelResponse struct {
+	Err error `json:"error,omitempty"`
+}
+
+func (r CancelResponse) Failed() error { return r.Err }
+
+func makeCancelEndpoint(s service.Service) kitendpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(CancelRequest)
+		err := s.Cancel(ctx, req.Number)
+		return CancelResponse{Err: err}, nil
+	}
+}
+
+// DeleteRequest - запрос на удаление посылки.
+type DeleteRequest struct {
+	Number int `json:"number"`
+}
+
+// DeleteResponse - ответ на удаление посылки.
+type DeleteResponse struct {
+	Err error `json:"error,omitempty"`
+}
+
+func (r DeleteResponse) Failed() error { return r.Err }
+
+func makeDeleteEndpoint(s service.Service) kitendpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(DeleteRequest)
+		_, err := s.Delete(ctx, req.Number)
+		return DeleteResponse{Err: err}, nil
+	}
+}
+
+// HistoryRequest - запрос на получение журнала событий посылки.
+type HistoryRequest struct {
+	Number int `json:"number"`
+}
+
+// HistoryResponse - ответ с журналом событий посылки.
+type HistoryResponse struct {
+	Events []store.ParcelEvent `json:"events"`
+	Err    error               `json:"error,omitempty"`
+}
+
+func (r HistoryResponse) Failed() error { return r.Err }
+
+func makeHistoryEndpoint(s service.Service) kitendpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(HistoryRequest)
+		events, err := s.History(ctx, req.Number)
+		return HistoryResponse{Events: events, Err: err}, nil
+	}
+}
@@ -2,193 +2,121 @@ package main
 
 import (
 	"database/sql"
+	"flag"
 	"fmt"
-	"time"
+	"net/http"
+	"os"
+
+	kitlog "github.com/go-kit/log"
+	_ "github.com/lib/pq" // Импорт драйвера Postgres
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	_ "modernc.org/sqlite" // Импорт драйвера SQLite
-)
 
-// Константы для определения статусов посылки.
-const (
-	ParcelStatusRegistered = "registered" // Посылка зарегистрирована.
-	ParcelStatusSent       = "sent"       // Посылка отправлена.
-	ParcelStatusDelivered  = "delivered"  // Посылка доставлена.
+	"github.com/ClownDUmpling/ParcelTrackerService/broker"
+	"github.com/ClownDUmpling/ParcelTrackerService/endpoint"
+	"github.com/ClownDUmpling/ParcelTrackerService/service"
+	"github.com/ClownDUmpling/ParcelTrackerService/store"
+	transporthttp "github.com/ClownDUmpling/ParcelTrackerService/transport/http"
 )
 
-// Parcel - структура, представляющая одну посылку.
-type Parcel struct {
-	Number    int    // Уникальный ID посылки.
-	Client    int    // ID клиента.
-	Status    string // Текущий статус.
-	Address   string // Адрес доставки.
-	CreatedAt string // Дата и время регистрации.
-}
-
-// ParcelService - сервис, реализующий бизнес-логику работы с посылками.
-type ParcelService struct {
-	store ParcelStore // Интерфейс к хранилищу данных (определен в parcel.go).
-}
-
-// NewParcelService - конструктор сервиса.
-func NewParcelService(store ParcelStore) ParcelService {
-	return ParcelService{store: store}
-}
+// main - поднимает HTTP/JSON сервис трекера посылок.
+func main() {
+	addr := flag.String("http.addr", ":8080", "адрес, на котором слушает HTTP-сервер")
+	dsn := flag.String("dsn", "tracker.db", "строка подключения к базе данных (путь к файлу для sqlite, DSN для postgres)")
+	storeKind := flag.String("store", "sqlite", "бэкенд хранилища: sqlite, memory или postgres")
+	storeTimeout := flag.Duration("store.timeout", service.DefaultTimeout, "таймаут одного вызова сервиса (всех его обращений к хранилищу)")
+	flag.Parse()
 
-// Register - регистрирует новую посылку в системе.
-func (s ParcelService) Register(client int, address string) (Parcel, error) {
-	parcel := Parcel{
-		Client:    client,
-		Status:    ParcelStatusRegistered, // Устанавливаем начальный статус.
-		Address:   address,
-		CreatedAt: time.Now().UTC().Format(time.RFC3339),
-	}
+	logger := kitlog.NewLogfmtLogger(kitlog.NewSyncWriter(os.Stderr))
+	logger = kitlog.With(logger, "ts", kitlog.DefaultTimestampUTC, "caller", kitlog.DefaultCaller)
 
-	// Добавляем посылку в хранилище и получаем ID.
-	id, err := s.store.Add(parcel)
+	parcelStore, err := newStore(*storeKind, *dsn)
 	if err != nil {
-		return parcel, err
+		logger.Log("err", err)
+		os.Exit(1)
 	}
 
-	parcel.Number = id
+	requestCount := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "parceltracker",
+		Name:      "requests_total",
+		Help:      "Количество запросов к сервису посылок по методам и статусам.",
+	}, []string{"method", "status"})
 
-	fmt.Printf("Новая посылка № %d на адрес %s от клиента с идентификатором %d зарегистрирована %s\n",
-		parcel.Number, parcel.Address, parcel.Client, parcel.CreatedAt)
+	requestLatency := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "parceltracker",
+		Name:      "request_duration_seconds",
+		Help:      "Длительность обработки запросов к сервису посылок.",
+	}, []string{"method", "status"})
 
-	return parcel, nil
-}
+	prometheus.MustRegister(requestCount, requestLatency)
 
-// PrintClientParcels - получает и выводит в консоль все посылки клиента.
-func (s ParcelService) PrintClientParcels(client int) error {
-	// Получаем список посылок из хранилища.
-	parcels, err := s.store.GetByClient(client)
+	msgBroker, err := newBroker(logger)
 	if err != nil {
-		return err
+		logger.Log("err", err)
+		os.Exit(1)
 	}
 
-	fmt.Printf("Посылки клиента %d:\n", client)
-	// Выводим данные каждой посылки.
-	for _, parcel := range parcels {
-		fmt.Printf("Посылка № %d на адрес %s от клиента с идентификатором %d зарегистрирована %s, статус %s\n",
-			parcel.Number, parcel.Address, parcel.Client, parcel.CreatedAt, parcel.Status)
-	}
-	fmt.Println()
+	// Инициализация сервиса, обернутого в middleware логирования, метрик
+	// и публикации событий в брокер сообщений.
+	var svc service.Service
+	svc = service.NewService(parcelStore, *storeTimeout)
+	svc = service.LoggingMiddleware(logger)(svc)
+	svc = service.InstrumentingMiddleware(requestCount, requestLatency)(svc)
+	svc = service.PublishingMiddleware(msgBroker)(svc)
 
-	return nil
-}
+	endpoints := endpoint.MakeServerEndpoints(svc)
+	httpHandler := transporthttp.NewHTTPHandler(endpoints, logger)
 
-// NextStatus - переводит посылку в следующий логический статус.
-func (s ParcelService) NextStatus(number int) error {
-	parcel, err := s.store.Get(number)
-	if err != nil {
-		return err
-	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.Handle("/", httpHandler)
 
-	var nextStatus string
-	// Логика перехода статусов.
-	switch parcel.Status {
-	case ParcelStatusRegistered:
-		nextStatus = ParcelStatusSent
-	case ParcelStatusSent:
-		nextStatus = ParcelStatusDelivered
-	case ParcelStatusDelivered:
-		return nil // Нельзя изменить статус доставленной посылки.
+	logger.Log("transport", "HTTP", "addr", *addr)
+	if err := http.ListenAndServe(*addr, mux); err != nil {
+		logger.Log("err", err)
+		os.Exit(1)
 	}
-
-	fmt.Printf("У посылки № %d новый статус: %s\n", number, nextStatus)
-
-	// Обновляем статус в БД.
-	return s.store.SetStatus(number, nextStatus)
-}
-
-// ChangeAddress - изменяет адрес доставки.
-func (s ParcelService) ChangeAddress(number int, address string) error {
-	return s.store.SetAddress(number, address)
 }
 
-// Delete - удаляет посылку.
-func (s ParcelService) Delete(number int) error {
-	return s.store.Delete(number)
-}
-
-// main - основная точка входа. Демонстрирует работу сервиса.
-func main() {
-	// Открытие соединения с базой данных SQLite.
-	db, err := sql.Open("sqlite", "tracker.db")
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
-	defer db.Close()
-
-	// Инициализация хранилища и сервиса.
-	store := NewParcelStore(db)
-	service := NewParcelService(store)
-
-	// --- Демонстрационный сценарий ---
-
-	// 1. Регистрация первой посылки.
-	client := 1
-	address := "Псков, д. Пушкина, ул. Колотушкина, д. 5"
-	p, err := service.Register(client, address)
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
-
-	// 2. Изменение адреса (допустимо, т.к. статус "registered").
-	newAddress := "Саратов, д. Верхние Зори, ул. Козлова, д. 25"
-	err = service.ChangeAddress(p.Number, newAddress)
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
-
-	// 3. Изменение статуса на "sent".
-	err = service.NextStatus(p.Number)
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
-
-	// 4. Вывод посылок клиента.
-	err = service.PrintClientParcels(client)
-	if err != nil {
-		fmt.Println(err)
-		return
+// newStore открывает хранилище выбранного бэкенда. Для sqlite и postgres dsn -
+// это строка подключения database/sql, для memory dsn игнорируется.
+func newStore(kind, dsn string) (store.Store, error) {
+	switch kind {
+	case "sqlite":
+		db, err := sql.Open("sqlite", dsn)
+		if err != nil {
+			return nil, err
+		}
+		return store.NewSQLiteStore(db), nil
+	case "postgres":
+		db, err := sql.Open("postgres", dsn)
+		if err != nil {
+			return nil, err
+		}
+		return store.NewPostgresStore(db), nil
+	case "memory":
+		return store.NewMemoryStore(), nil
+	default:
+		return nil, fmt.Errorf("newStore: unknown store backend %q", kind)
 	}
+}
 
-	// 5. Попытка удаления отправленной посылки (должна завершиться ошибкой).
-	err = service.Delete(p.Number)
-	if err != nil {
-		fmt.Println(err) // Вывод ожидаемой ошибки.
-		return
+// newBroker поднимает Kafka-брокер, если адреса брокеров заданы через
+// KAFKA_HOST, и откатывается на NoopBroker иначе - так сервис может
+// работать локально без поднятой Kafka.
+func newBroker(logger kitlog.Logger) (broker.Broker, error) {
+	if os.Getenv("KAFKA_HOST") == "" {
+		return broker.NewNoopBroker(), nil
 	}
 
-	// 6. Повторный вывод посылок (первая посылка не удалилась).
-	err = service.PrintClientParcels(client)
+	b, err := broker.NewKafkaBroker(broker.KafkaConfigFromEnv())
 	if err != nil {
-		fmt.Println(err)
-		return
+		return nil, err
 	}
 
-	// 7. Регистрация новой посылки.
-	p, err = service.Register(client, address)
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
+	logger.Log("broker", "kafka", "hosts", os.Getenv("KAFKA_HOST"))
 
-	// 8. Удаление новой посылки (должно пройти успешно, т.к. статус "registered").
-	err = service.Delete(p.Number)
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
-
-	// 9. Финальный вывод посылок (вторая посылка должна отсутствовать).
-	err = service.PrintClientParcels(client)
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
+	return b, nil
 }
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ClownDUmpling/ParcelTrackerService/store"
+)
+
+// Middleware - декоратор над Service, используется для логирования,
+// сбора метрик и прочих сквозных забот, не связанных с бизнес-логикой.
+type Middleware func(Service) Service
+
+// LoggingMiddleware возвращает Middleware, логирующее каждый вызов сервиса
+// вместе с его длительностью и ошибкой (если была).
+func LoggingMiddleware(logger log.Logger) Middleware {
+	return func(next Service) Service {
+		return loggingMiddleware{logger: logger, next: next}
+	}
+}
+
+type loggingMiddleware struct {
+	logger log.Logger
+	next   Service
+}
+
+func (mw loggingMiddleware) Register(ctx context.Context, client int, address string) (p store.Parcel, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Register", "client", client, "address", address,
+			"number", p.Number, "took", time.Since(begin), "err", err)
+	}(time.Now())
+
+	return mw.next.Register(ctx, client, address)
+}
+
+func (mw loggingMiddleware) Get(ctx context.Context, number int) (p store.Parcel, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Get", "number", number, "took", time.Since(begin), "err", err)
+	}(time.Now())
+
+	return mw.next.Get(ctx, number)
+}
+
+func (mw loggingMiddleware) GetByClient(ctx context.Context, client int) (parcels []store.Parcel, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "GetByClient", "client", client, "count", len(parcels),
+			"took", time.Since(begin), "err", err)
+	}(time.Now())
+
+	return mw.next.GetByClient(ctx, client)
+}
+
+func (mw loggingMiddleware) NextStatus(ctx context.Context, number int) (err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "NextStatus", "number", number, "took", time.Since(begin), "err", err)
+	}(time.Now())
+
+	return mw.next.NextStatus(ctx, number)
+}
+
+func (mw loggingMiddleware) Cancel(ctx context.Context, number int) (err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Cancel", "number", number, "took", time.Since(begin), "err", err)
+	}(time.Now())
+
+	return mw.next.Cancel(ctx, number)
+}
+
+func (mw loggingMiddleware) ChangeAddress(ctx context.Context, number int, address string) (changed bool, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "ChangeAddress", "number", number, "address", address,
+			"changed", changed, "took", time.Since(begin), "err", err)
+	}(time.Now())
+
+	return mw.next.ChangeAddress(ctx, number, address)
+}
+
+func (mw loggingMiddleware) Delete(ctx context.Context, number int) (changed bool, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Delete", "number", number, "changed", changed, "took", time.Since(begin), "err", err)
+	}(time.Now())
+
+	return mw.next.Delete(ctx, number)
+}
+
+func (mw loggingMiddleware) History(ctx context.Context, number int) (events []store.ParcelEvent, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "History", "number", number, "count", len(events),
+			"took", time.Since(begin), "err", err)
+	}(time.Now())
+
+	return mw.next.History(ctx, number)
+}
+
+// InstrumentingMiddleware возвращает Middleware, публикующее Prometheus-style
+// счетчики запросов и гистограммы длительности для каждого метода сервиса.
+func InstrumentingMiddleware(requestCount *prometheus.CounterVec, requestLatency *prometheus.HistogramVec) Middleware {
+	return func(next Service) Service {
+		return instrumentingMiddleware{
+			requestCount:   requestCount,
+			requestLatency: requestLatency,
+			next:           next,
+		}
+	}
+}
+
+type instrumentingMiddleware struct {
+	requestCount   *prometheus.CounterVec
+	requestLatency *prometheus.HistogramVec
+	next           Service
+}
+
+func (mw instrumentingMiddleware) observe(method string, begin time.Time, err error) {
+	status := "ok"
+	if err != nil {
+		status = "error"
+	}
+
+	mw.requestCount.With(prometheus.Labels{"method": method, "status": status}).Inc()
+	mw.requestLatency.With(prometheus.Labels{"method": method, "status": status}).Observe(time.Since(begin).Seconds())
+}
+
+func (mw instrumentingMiddleware) Register(ctx context.Context, client int, address string) (store.Parcel, error) {
+	begin := time.Now()
+	p, err := mw.next.Register(ctx, client, address)
+	mw.observe("Register", begin, err)
+	return p, err
+}
+
+func (mw instrumentingMiddleware) Get(ctx context.Context, number int) (store.Parcel, error) {
+	begin := time.Now()
+	p, err := mw.next.Get(ctx, number)
+	mw.observe("Get", begin, err)
+	return p, err
+}
+
+func (mw instrumentingMiddleware) GetByClient(ctx context.Context, client int) ([]store.Parcel, error) {
+	begin := time.Now()
+	parcels, err := mw.next.GetByClient(ctx, client)
+	mw.observe("GetByClient", begin, err)
+	return parcels, err
+}
+
+func (mw instrumentingMiddleware) NextStatus(ctx context.Context, number int) error {
+	begin := time.Now()
+	err := mw.next.NextStatus(ctx, number)
+	mw.observe("NextStatus", begin, err)
+	return err
+}
+
+func (mw instrumentingMiddleware) Cancel(ctx context.Context, number int) error {
+	begin := time.Now()
+	err := mw.next.Cancel(ctx, number)
+	mw.observe("Cancel", begin, err)
+	return err
+}
+
+func (mw instrumentingMiddleware) ChangeAddress(ctx context.Context, number int, address string) (bool, error) {
+	begin := time.Now()
+	changed, err := mw.next.ChangeAddress(ctx, number, address)
+	mw.observe("ChangeAddress", begin, err)
+	return changed, err
+}
+
+func (mw instrumentingMiddleware) Delete(ctx context.Context, number int) (bool, error) {
+	begin := time.Now()
+	changed, err := mw.next.Delete(ctx, number)
+	mw.observe("Delete", begin, err)
+	return changed, err
+}
+
+func (mw instrumentingMiddleware) History(ctx context.Context, number int) ([]store.ParcelEvent, error) {
+	begin := time.Now()
+	events, err := mw.next.History(ctx, number)
+	mw.observe("History", begin, err)
+	return events, err
+}
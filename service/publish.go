@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ClownDUmpling/ParcelTrackerService/broker"
+	"github.com/ClownDUmpling/ParcelTrackerService/store"
+)
+
+// Топики (без учета TopicPrefix брокера), на которые сервис публикует
+// события об изменении посылок.
+const (
+	topicRegistered     = "registered"
+	topicStatusChanged  = "status_changed"
+	topicAddressChanged = "address_changed"
+	topicDeleted        = "deleted"
+	topicCancelled      = "cancelled"
+)
+
+// PublishingMiddleware возвращает Middleware, публикующее в b событие о
+// каждой успешной мутации посылки - регистрации, смене статуса, смене
+// адреса и удалении. Ошибки публикации не влияют на результат вызова:
+// сервис остается источником истины, брокер - лишь его слушателем.
+func PublishingMiddleware(b broker.Broker) Middleware {
+	return func(next Service) Service {
+		return publishingMiddleware{broker: b, next: next}
+	}
+}
+
+type publishingMiddleware struct {
+	broker broker.Broker
+	next   Service
+}
+
+func (mw publishingMiddleware) Register(ctx context.Context, client int, address string) (store.Parcel, error) {
+	p, err := mw.next.Register(ctx, client, address)
+	if err == nil {
+		mw.publish(topicRegistered, p)
+	}
+
+	return p, err
+}
+
+func (mw publishingMiddleware) Get(ctx context.Context, number int) (store.Parcel, error) {
+	return mw.next.Get(ctx, number)
+}
+
+func (mw publishingMiddleware) GetByClient(ctx context.Context, client int) ([]store.Parcel, error) {
+	return mw.next.GetByClient(ctx, client)
+}
+
+func (mw publishingMiddleware) NextStatus(ctx context.Context, number int) error {
+	err := mw.next.NextStatus(ctx, number)
+	if err == nil {
+		mw.publishParcel(ctx, topicStatusChanged, number)
+	}
+
+	return err
+}
+
+func (mw publishingMiddleware) Cancel(ctx context.Context, number int) error {
+	err := mw.next.Cancel(ctx, number)
+	if err == nil {
+		mw.publishParcel(ctx, topicCancelled, number)
+	}
+
+	return err
+}
+
+func (mw publishingMiddleware) ChangeAddress(ctx context.Context, number int, address string) (bool, error) {
+	changed, err := mw.next.ChangeAddress(ctx, number, address)
+	if err == nil && changed {
+		mw.publishParcel(ctx, topicAddressChanged, number)
+	}
+
+	return changed, err
+}
+
+func (mw publishingMiddleware) Delete(ctx context.Context, number int) (bool, error) {
+	changed, err := mw.next.Delete(ctx, number)
+	if err == nil && changed {
+		mw.publish(topicDeleted, struct {
+			Number int `json:"number"`
+		}{Number: number})
+	}
+
+	return changed, err
+}
+
+func (mw publishingMiddleware) History(ctx context.Context, number int) ([]store.ParcelEvent, error) {
+	return mw.next.History(ctx, number)
+}
+
+// publishParcel перечитывает посылку и публикует ее текущее состояние.
+// Используется там, где мутирующий метод сервиса не возвращает саму посылку.
+func (mw publishingMiddleware) publishParcel(ctx context.Context, topic string, number int) {
+	p, err := mw.next.Get(ctx, number)
+	if err != nil {
+		return
+	}
+
+	mw.publish(topic, p)
+}
+
+// publish сериализует event в JSON и публикует его, не прерывая вызов
+// при ошибке брокера.
+func (mw publishingMiddleware) publish(topic string, event interface{}) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	_ = mw.broker.Publish(topic, payload)
+}
@@ -0,0 +1,149 @@
+// Package service содержит бизнес-логику работы с посылками,
+// независимую от конкретного транспорта (CLI, HTTP, gRPC).
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ClownDUmpling/ParcelTrackerService/store"
+)
+
+// DefaultTimeout - таймаут на один вызов метода сервиса (вместе со всеми его
+// обращениями к store.Store) по умолчанию. Он накладывается поверх ctx
+// вызывающей стороны: если ее дедлайн наступает раньше, действует он.
+const DefaultTimeout = 2 * time.Second
+
+// Service - бизнес-логика трекера посылок. Транспортные уровни (CLI, HTTP, ...)
+// работают только через этот интерфейс и не знают о хранилище напрямую.
+type Service interface {
+	Register(ctx context.Context, client int, address string) (store.Parcel, error)
+	Get(ctx context.Context, number int) (store.Parcel, error)
+	GetByClient(ctx context.Context, client int) ([]store.Parcel, error)
+	NextStatus(ctx context.Context, number int) error
+	Cancel(ctx context.Context, number int) error
+	// ChangeAddress и Delete возвращают changed == false и nil error, если
+	// посылка не в статусе 'registered' - это не ошибка, а молчаливый no-op.
+	ChangeAddress(ctx context.Context, number int, address string) (changed bool, err error)
+	Delete(ctx context.Context, number int) (changed bool, err error)
+	History(ctx context.Context, number int) ([]store.ParcelEvent, error)
+}
+
+// parcelService - реализация Service поверх store.Store.
+type parcelService struct {
+	store   store.Store
+	timeout time.Duration
+}
+
+// NewService - конструктор сервиса. timeout ограничивает длительность
+// каждого вызова метода сервиса, включая все его обращения к store.Store;
+// если передан <= 0, используется DefaultTimeout.
+func NewService(s store.Store, timeout time.Duration) Service {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	return parcelService{store: s, timeout: timeout}
+}
+
+// withTimeout ограничивает ctx таймаутом сервиса, не давая медленному
+// вызову БД держать HTTP-запрос (и горутину) дольше положенного.
+func (s parcelService) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, s.timeout)
+}
+
+// Register - регистрирует новую посылку в системе.
+func (s parcelService) Register(ctx context.Context, client int, address string) (store.Parcel, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	parcel := store.Parcel{
+		Client:    client,
+		Status:    store.ParcelStatusRegistered, // Устанавливаем начальный статус.
+		Address:   address,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	// Добавляем посылку в хранилище и получаем ID.
+	id, err := s.store.Add(ctx, parcel)
+	if err != nil {
+		return parcel, err
+	}
+
+	parcel.Number = id
+
+	return parcel, nil
+}
+
+// Get - возвращает одну посылку по номеру.
+func (s parcelService) Get(ctx context.Context, number int) (store.Parcel, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	return s.store.Get(ctx, number)
+}
+
+// GetByClient - возвращает все посылки клиента.
+func (s parcelService) GetByClient(ctx context.Context, client int) ([]store.Parcel, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	return s.store.GetByClient(ctx, client)
+}
+
+// NextStatus - переводит посылку в следующий логический статус по цепочке
+// registered -> sent -> delivered. Возвращает store.ErrTerminalState, если
+// посылка уже в терминальном статусе (delivered, cancelled), вместо того
+// чтобы молча ничего не делать.
+func (s parcelService) NextStatus(ctx context.Context, number int) error {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	parcel, err := s.store.Get(ctx, number)
+	if err != nil {
+		return err
+	}
+
+	nextStatus, err := store.NextLinearStatus(parcel.Status)
+	if err != nil {
+		return err
+	}
+
+	// Обновляем статус в БД; store.Store повторно проверяет переход.
+	return s.store.SetStatus(ctx, number, nextStatus)
+}
+
+// Cancel - отменяет посылку. Разрешено только пока посылка в статусе
+// 'registered'; в остальных случаях возвращает store.ErrInvalidTransition
+// или store.ErrTerminalState.
+func (s parcelService) Cancel(ctx context.Context, number int) error {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	return s.store.SetStatus(ctx, number, store.ParcelStatusCancelled)
+}
+
+// ChangeAddress - изменяет адрес доставки.
+func (s parcelService) ChangeAddress(ctx context.Context, number int, address string) (bool, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	return s.store.SetAddress(ctx, number, address)
+}
+
+// Delete - удаляет посылку.
+func (s parcelService) Delete(ctx context.Context, number int) (bool, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	return s.store.Delete(ctx, number)
+}
+
+// History - возвращает полный журнал изменений посылки: регистрацию,
+// смены статуса и адреса, удаление - в хронологическом порядке.
+func (s parcelService) History(ctx context.Context, number int) ([]store.ParcelEvent, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	return s.store.History(ctx, number)
+}
@@ -0,0 +1,30 @@
+package store
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// countingContext reports itself as cancelled only once its Err() method has
+// been called more than n times. Used to make a cancellation land partway
+// through an in-flight row-scanning loop (GetByClient) instead of always
+// firing before the first iteration, regardless of how many rows a backend
+// happens to process before checking ctx.Err() again.
+type countingContext struct {
+	context.Context
+	remaining *int32
+}
+
+// newCountingContext returns a context whose Err() is nil for the first n
+// calls and context.Canceled from the (n+1)-th call on.
+func newCountingContext(parent context.Context, n int32) context.Context {
+	remaining := n
+	return countingContext{Context: parent, remaining: &remaining}
+}
+
+func (c countingContext) Err() error {
+	if atomic.AddInt32(c.remaining, -1) < 0 {
+		return context.Canceled
+	}
+	return nil
+}
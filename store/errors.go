@@ -0,0 +1,11 @@
+package store
+
+import "errors"
+
+// ErrNotFound оборачивается с номером посылки в Get, SetStatus и SetAddress
+// реализаций Store, когда запрошенная посылка не существует - независимо от
+// бэкенда, чтобы вызывающая сторона (включая транспортный слой) могла
+// сопоставить ее с 404, а не получить протекающую наружу sql.ErrNoRows.
+// Delete несуществующей посылки возвращает changed == false и nil error,
+// History - пустой журнал.
+var ErrNotFound = errors.New("parcel not found")
@@ -0,0 +1,24 @@
+package store
+
+// Типы событий, которые попадают в журнал parcel_event.
+const (
+	EventRegistered     = "registered"      // Посылка создана.
+	EventStatusChanged  = "status_changed"  // Изменился статус посылки.
+	EventAddressChanged = "address_changed" // Изменился адрес доставки.
+	EventDeleted        = "deleted"         // Посылка удалена.
+)
+
+// defaultActor используется, пока у сервиса нет отдельного слоя аутентификации,
+// который мог бы передать настоящего инициатора изменения.
+const defaultActor = "system"
+
+// ParcelEvent - одна запись в журнале изменений посылки (таблица parcel_event).
+type ParcelEvent struct {
+	ID           int    // Уникальный ID события.
+	ParcelNumber int    // Номер посылки, к которой относится событие.
+	EventType    string // Тип события (EventRegistered, EventStatusChanged, ...).
+	OldValue     string // Значение поля до изменения (пусто для EventRegistered).
+	NewValue     string // Значение поля после изменения.
+	Actor        string // Инициатор изменения.
+	OccurredAt   string // Дата и время события.
+}
@@ -0,0 +1,198 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MemoryStore - реализация Store поверх карты в памяти процесса.
+// Полезна для тестов и для демонстраций (например, -store memory в main),
+// не требующих настоящего файла базы данных.
+type MemoryStore struct {
+	mu          *sync.Mutex
+	parcels     map[int]Parcel
+	events      map[int][]ParcelEvent
+	nextID      *int
+	nextEventID *int
+}
+
+// NewMemoryStore - конструктор для MemoryStore.
+func NewMemoryStore() MemoryStore {
+	nextID := 1
+	nextEventID := 1
+	return MemoryStore{
+		mu:          &sync.Mutex{},
+		parcels:     make(map[int]Parcel),
+		events:      make(map[int][]ParcelEvent),
+		nextID:      &nextID,
+		nextEventID: &nextEventID,
+	}
+}
+
+// Add - добавляет новую посылку, присваивая ей очередной номер, и пишет
+// событие EventRegistered в ее журнал.
+func (s MemoryStore) Add(ctx context.Context, p Parcel) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := *s.nextID
+	*s.nextID++
+
+	p.Number = id
+	s.parcels[id] = p
+
+	s.appendEvent(id, EventRegistered, "", p.Status)
+
+	return id, nil
+}
+
+// Get - извлекает информацию о посылке по ее уникальному номеру (Number).
+func (s MemoryStore) Get(ctx context.Context, number int) (Parcel, error) {
+	if err := ctx.Err(); err != nil {
+		return Parcel{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.parcels[number]
+	if !ok {
+		return Parcel{}, fmt.Errorf("parcel %d: %w", number, ErrNotFound)
+	}
+
+	return p, nil
+}
+
+// GetByClient - извлекает список всех посылок для конкретного клиента.
+func (s MemoryStore) GetByClient(ctx context.Context, client int) ([]Parcel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var res []Parcel
+	for _, p := range s.parcels {
+		// Проверяем ctx на каждой итерации, как и настоящие БД-реализации
+		// Store делают через rows.Next().
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if p.Client == client {
+			res = append(res, p)
+		}
+	}
+
+	return res, nil
+}
+
+// SetStatus - обновляет статус посылки по ее номеру и пишет событие
+// EventStatusChanged в ее журнал.
+func (s MemoryStore) SetStatus(ctx context.Context, number int, status string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.parcels[number]
+	if !ok {
+		return fmt.Errorf("parcel %d: %w", number, ErrNotFound)
+	}
+
+	if err := ValidateTransition(p.Status, status); err != nil {
+		return err
+	}
+
+	old := p.Status
+	p.Status = status
+	s.parcels[number] = p
+
+	s.appendEvent(number, EventStatusChanged, old, status)
+
+	return nil
+}
+
+// SetAddress - обновляет адрес посылки по ее номеру и пишет событие
+// EventAddressChanged в ее журнал.
+// Обратите внимание: изменение адреса возможно ТОЛЬКО, если статус 'registered'.
+func (s MemoryStore) SetAddress(ctx context.Context, number int, address string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.parcels[number]
+	if !ok {
+		return false, fmt.Errorf("parcel %d: %w", number, ErrNotFound)
+	}
+	if p.Status != ParcelStatusRegistered {
+		return false, nil
+	}
+
+	old := p.Address
+	p.Address = address
+	s.parcels[number] = p
+
+	s.appendEvent(number, EventAddressChanged, old, address)
+
+	return true, nil
+}
+
+// Delete - удаляет посылку по ее номеру и пишет событие EventDeleted
+// в ее журнал.
+// Обратите внимание: удаление возможно ТОЛЬКО, если статус 'registered'.
+func (s MemoryStore) Delete(ctx context.Context, number int) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.parcels[number]
+	if !ok || p.Status != ParcelStatusRegistered {
+		return false, nil
+	}
+
+	delete(s.parcels, number)
+
+	s.appendEvent(number, EventDeleted, p.Status, "")
+
+	return true, nil
+}
+
+// History возвращает журнал событий посылки в хронологическом порядке.
+func (s MemoryStore) History(ctx context.Context, number int) ([]ParcelEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]ParcelEvent(nil), s.events[number]...), nil
+}
+
+// appendEvent добавляет событие в журнал посылки. Вызывается под s.mu.
+func (s MemoryStore) appendEvent(number int, eventType, oldValue, newValue string) {
+	id := *s.nextEventID
+	*s.nextEventID++
+
+	s.events[number] = append(s.events[number], ParcelEvent{
+		ID:           id,
+		ParcelNumber: number,
+		EventType:    eventType,
+		OldValue:     oldValue,
+		NewValue:     newValue,
+		Actor:        defaultActor,
+		OccurredAt:   time.Now().UTC().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,93 @@
+package store
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMemoryStoreAddGet(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+
+	number, err := s.Add(ctx, Parcel{Client: 1, Status: ParcelStatusRegistered, Address: "Псков"})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	p, err := s.Get(ctx, number)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if p.Client != 1 || p.Address != "Псков" {
+		t.Fatalf("Get() = %+v, want client 1 and address Псков", p)
+	}
+}
+
+func TestMemoryStoreDeleteOnlyRegistered(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+
+	number, err := s.Add(ctx, Parcel{Client: 1, Status: ParcelStatusSent})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	changed, err := s.Delete(ctx, number)
+	if err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if changed {
+		t.Fatalf("Delete() on a non-registered parcel changed = true, want false")
+	}
+
+	if _, err := s.Get(ctx, number); err != nil {
+		t.Fatalf("Get() after Delete() on a non-registered parcel = %v, want parcel to remain", err)
+	}
+}
+
+func TestMemoryStoreHistory(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+
+	number, err := s.Add(ctx, Parcel{Client: 1, Status: ParcelStatusRegistered, Address: "Псков"})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	if err := s.SetStatus(ctx, number, ParcelStatusSent); err != nil {
+		t.Fatalf("SetStatus() error = %v", err)
+	}
+
+	events, err := s.History(ctx, number)
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("History() = %d events, want 2", len(events))
+	}
+	if events[0].EventType != EventRegistered || events[1].EventType != EventStatusChanged {
+		t.Fatalf("History() = %+v, want [registered, status_changed]", events)
+	}
+}
+
+func TestMemoryStoreGetByClientAbortsOnCancelledContext(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+
+	const numParcels = 5
+	for i := 0; i < numParcels; i++ {
+		if _, err := s.Add(ctx, Parcel{Client: 1, Status: ParcelStatusRegistered}); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	// Let the scan process a couple of rows before reporting cancellation,
+	// so the test exercises the mid-loop ctx.Err() check rather than the
+	// top-of-loop guard on the very first iteration.
+	partway := newCountingContext(ctx, 2)
+
+	if _, err := s.GetByClient(partway, 1); err != context.Canceled {
+		t.Fatalf("GetByClient() cancelled mid-scan = %v, want context.Canceled", err)
+	}
+}
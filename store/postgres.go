@@ -0,0 +1,249 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	_ "github.com/lib/pq" // Импорт драйвера Postgres.
+)
+
+// PostgresStore - реализация Store поверх database/sql + lib/pq.
+// В отличие от SQLiteStore использует позиционные плейсхолдеры ($1, $2, ...)
+// вместо именованных параметров, как того требует протокол Postgres.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore - конструктор для PostgresStore.
+func NewPostgresStore(db *sql.DB) PostgresStore {
+	return PostgresStore{db: db}
+}
+
+// Add - добавляет новую посылку в таблицу 'parcel' и пишет событие
+// EventRegistered в parcel_event в рамках одной транзакции.
+// Возвращает присвоенный ID (Number) новой посылки.
+func (s PostgresStore) Add(ctx context.Context, p Parcel) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var id int
+
+	// Postgres не поддерживает LastInsertId, поэтому возвращаем id через RETURNING.
+	row := tx.QueryRowContext(ctx, "INSERT INTO parcel (client, status, address, created_at) VALUES ($1, $2, $3, $4) RETURNING number",
+		p.Client, p.Status, p.Address, p.CreatedAt)
+
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+
+	if err := pgInsertEvent(ctx, tx, id, EventRegistered, "", p.Status); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// Get - извлекает информацию о посылке по ее уникальному номеру (Number).
+func (s PostgresStore) Get(ctx context.Context, number int) (Parcel, error) {
+	p := Parcel{}
+
+	row := s.db.QueryRowContext(ctx, "SELECT number, client, status, address, created_at FROM parcel WHERE number = $1", number)
+
+	err := row.Scan(&p.Number, &p.Client, &p.Status, &p.Address, &p.CreatedAt)
+	if err != nil {
+		return p, wrapNotFound(number, err)
+	}
+
+	return p, nil
+}
+
+// GetByClient - извлекает список всех посылок для конкретного клиента.
+func (s PostgresStore) GetByClient(ctx context.Context, client int) ([]Parcel, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT number, client, status, address, created_at FROM parcel WHERE client = $1", client)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() // Обязательно закрываем курсор.
+
+	var res []Parcel
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		p := Parcel{}
+
+		if err := rows.Scan(&p.Number, &p.Client, &p.Status, &p.Address, &p.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		res = append(res, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// SetStatus - обновляет статус посылки по ее номеру и пишет событие
+// EventStatusChanged в рамках одной транзакции.
+func (s PostgresStore) SetStatus(ctx context.Context, number int, status string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	old, err := pgGetParcel(ctx, tx, number)
+	if err != nil {
+		return err
+	}
+
+	if err := ValidateTransition(old.Status, status); err != nil {
+		return err
+	}
+
+	// WHERE status = $3 - защита от гонки: если статус успел измениться
+	// между SELECT и UPDATE, строка не обновится, и RowsAffected == 0.
+	res, err := tx.ExecContext(ctx, "UPDATE parcel SET status = $1 WHERE number = $2 AND status = $3", status, number, old.Status)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrInvalidTransition
+	}
+
+	if err := pgInsertEvent(ctx, tx, number, EventStatusChanged, old.Status, status); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetAddress - обновляет адрес посылки по ее номеру и пишет событие
+// EventAddressChanged в рамках одной транзакции.
+// Обратите внимание: изменение адреса возможно ТОЛЬКО, если статус 'registered'.
+func (s PostgresStore) SetAddress(ctx context.Context, number int, address string) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	old, err := pgGetParcel(ctx, tx, number)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, "UPDATE parcel SET address = $1 WHERE number = $2 AND status = $3",
+		address, number, ParcelStatusRegistered)
+	if err != nil {
+		return false, err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return false, err
+	} else if n == 0 {
+		return false, tx.Commit() // Статус не 'registered' - молча ничего не меняем, как и раньше.
+	}
+
+	if err := pgInsertEvent(ctx, tx, number, EventAddressChanged, old.Address, address); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// Delete - удаляет посылку по ее номеру и пишет событие EventDeleted
+// в рамках одной транзакции.
+// Обратите внимание: удаление возможно ТОЛЬКО, если статус 'registered'.
+func (s PostgresStore) Delete(ctx context.Context, number int) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM parcel WHERE number = $1 AND status = $2", number, ParcelStatusRegistered)
+	if err != nil {
+		return false, err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return false, err
+	} else if n == 0 {
+		return false, tx.Commit() // Статус не 'registered' - молча ничего не меняем, как и раньше.
+	}
+
+	if err := pgInsertEvent(ctx, tx, number, EventDeleted, ParcelStatusRegistered, ""); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// History возвращает журнал событий посылки в хронологическом порядке.
+func (s PostgresStore) History(ctx context.Context, number int) ([]ParcelEvent, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT id, parcel_number, event_type, old_value, new_value, actor, occurred_at FROM parcel_event WHERE parcel_number = $1 ORDER BY id", number)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []ParcelEvent
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		e := ParcelEvent{}
+
+		if err := rows.Scan(&e.ID, &e.ParcelNumber, &e.EventType, &e.OldValue, &e.NewValue, &e.Actor, &e.OccurredAt); err != nil {
+			return nil, err
+		}
+
+		res = append(res, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// pgGetParcel читает текущее состояние посылки в рамках открытой транзакции,
+// чтобы знать old_value для события до применения UPDATE.
+func pgGetParcel(ctx context.Context, tx *sql.Tx, number int) (Parcel, error) {
+	p := Parcel{}
+
+	row := tx.QueryRowContext(ctx, "SELECT number, client, status, address, created_at FROM parcel WHERE number = $1", number)
+
+	err := row.Scan(&p.Number, &p.Client, &p.Status, &p.Address, &p.CreatedAt)
+	if err != nil {
+		return p, wrapNotFound(number, err)
+	}
+
+	return p, nil
+}
+
+// pgInsertEvent пишет одну строку в parcel_event в рамках переданной транзакции.
+func pgInsertEvent(ctx context.Context, tx *sql.Tx, number int, eventType, oldValue, newValue string) error {
+	_, err := tx.ExecContext(ctx, "INSERT INTO parcel_event (parcel_number, event_type, old_value, new_value, actor, occurred_at) VALUES ($1, $2, $3, $4, $5, $6)",
+		number, eventType, oldValue, newValue, defaultActor, time.Now().UTC().Format(time.RFC3339))
+
+	return err
+}
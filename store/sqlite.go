@@ -0,0 +1,287 @@
+package store
+
+import (
+	"context"
+	"database/sql" // Для работы с базой данных и SQL.
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SQLiteStore - реализация Store поверх database/sql + modernc.org/sqlite.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore - конструктор для SQLiteStore.
+func NewSQLiteStore(db *sql.DB) SQLiteStore {
+	return SQLiteStore{db: db}
+}
+
+// Add - добавляет новую посылку в таблицу 'parcel' и пишет событие
+// EventRegistered в parcel_event в рамках одной транзакции.
+// Возвращает присвоенный ID (Number) новой посылки.
+func (s SQLiteStore) Add(ctx context.Context, p Parcel) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// Выполняем INSERT-запрос, используя именованные параметры.
+	res, err := tx.ExecContext(ctx, "INSERT INTO parcel (client, status, address, created_at) VALUES (:client, :status, :address, :created_at)",
+		sql.Named("client", p.Client),
+		sql.Named("status", p.Status),
+		sql.Named("address", p.Address),
+		sql.Named("created_at", p.CreatedAt))
+	if err != nil {
+		return 0, err
+	}
+
+	// Получаем ID, автоматически сгенерированный базой данных.
+	id64, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	id := int(id64)
+
+	if err := insertEvent(ctx, tx, id, EventRegistered, "", p.Status); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// Get - извлекает информацию о посылке по ее уникальному номеру (Number).
+func (s SQLiteStore) Get(ctx context.Context, number int) (Parcel, error) {
+	p := Parcel{}
+
+	// Выполняем SELECT-запрос для получения одной строки.
+	row := s.db.QueryRowContext(ctx, "SELECT number, client, status, address, created_at FROM parcel WHERE number = :number",
+		sql.Named("number", number))
+
+	// Сканируем полученные значения в структуру Parcel.
+	err := row.Scan(&p.Number, &p.Client, &p.Status, &p.Address, &p.CreatedAt)
+	if err != nil {
+		return p, wrapNotFound(number, err)
+	}
+
+	return p, nil
+}
+
+// GetByClient - извлекает список всех посылок для конкретного клиента.
+func (s SQLiteStore) GetByClient(ctx context.Context, client int) ([]Parcel, error) {
+	// Выполняем SELECT-запрос, который может вернуть несколько строк.
+	rows, err := s.db.QueryContext(ctx, "SELECT number, client, status, address, created_at FROM parcel WHERE client = :client",
+		sql.Named("client", client))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() // Обязательно закрываем курсор.
+
+	var res []Parcel
+	// Итерируемся по всем полученным строкам, прерываясь при отмене ctx.
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		p := Parcel{}
+
+		// Сканируем текущую строку в структуру.
+		err := rows.Scan(&p.Number, &p.Client, &p.Status, &p.Address, &p.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, p)
+	}
+
+	// Проверяем, не было ли ошибок при итерации по строкам.
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// SetStatus - обновляет статус посылки по ее номеру и пишет событие
+// EventStatusChanged в рамках одной транзакции.
+func (s SQLiteStore) SetStatus(ctx context.Context, number int, status string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	old, err := txGetParcel(ctx, tx, number)
+	if err != nil {
+		return err
+	}
+
+	if err := ValidateTransition(old.Status, status); err != nil {
+		return err
+	}
+
+	// WHERE status = :old_status - защита от гонки: если статус успел
+	// измениться между SELECT и UPDATE, строка не обновится, и RowsAffected == 0.
+	res, err := tx.ExecContext(ctx, "UPDATE parcel SET status = :status WHERE number = :number AND status = :old_status",
+		sql.Named("status", status),
+		sql.Named("number", number),
+		sql.Named("old_status", old.Status))
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrInvalidTransition
+	}
+
+	if err := insertEvent(ctx, tx, number, EventStatusChanged, old.Status, status); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetAddress - обновляет адрес посылки по ее номеру и пишет событие
+// EventAddressChanged в рамках одной транзакции.
+// Обратите внимание: изменение адреса возможно ТОЛЬКО, если статус 'registered'.
+func (s SQLiteStore) SetAddress(ctx context.Context, number int, address string) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	old, err := txGetParcel(ctx, tx, number)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, "UPDATE parcel SET address = :address WHERE number = :number AND status = :status",
+		sql.Named("address", address),
+		sql.Named("number", number),
+		sql.Named("status", ParcelStatusRegistered))
+	if err != nil {
+		return false, err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return false, err
+	} else if n == 0 {
+		return false, tx.Commit() // Статус не 'registered' - молча ничего не меняем, как и раньше.
+	}
+
+	if err := insertEvent(ctx, tx, number, EventAddressChanged, old.Address, address); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// Delete - удаляет посылку по ее номеру и пишет событие EventDeleted
+// в рамках одной транзакции.
+// Обратите внимание: удаление возможно ТОЛЬКО, если статус 'registered'.
+func (s SQLiteStore) Delete(ctx context.Context, number int) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM parcel WHERE number = :number AND status = :status",
+		sql.Named("number", number),
+		sql.Named("status", ParcelStatusRegistered)) // Используем константу статуса.
+	if err != nil {
+		return false, err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return false, err
+	} else if n == 0 {
+		return false, tx.Commit() // Статус не 'registered' - молча ничего не меняем, как и раньше.
+	}
+
+	if err := insertEvent(ctx, tx, number, EventDeleted, ParcelStatusRegistered, ""); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// History возвращает журнал событий посылки в хронологическом порядке.
+func (s SQLiteStore) History(ctx context.Context, number int) ([]ParcelEvent, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT id, parcel_number, event_type, old_value, new_value, actor, occurred_at FROM parcel_event WHERE parcel_number = :number ORDER BY id",
+		sql.Named("number", number))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []ParcelEvent
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		e := ParcelEvent{}
+
+		if err := rows.Scan(&e.ID, &e.ParcelNumber, &e.EventType, &e.OldValue, &e.NewValue, &e.Actor, &e.OccurredAt); err != nil {
+			return nil, err
+		}
+
+		res = append(res, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// txGetParcel читает текущее состояние посылки в рамках открытой транзакции,
+// чтобы знать old_value для события до применения UPDATE.
+func txGetParcel(ctx context.Context, tx *sql.Tx, number int) (Parcel, error) {
+	p := Parcel{}
+
+	row := tx.QueryRowContext(ctx, "SELECT number, client, status, address, created_at FROM parcel WHERE number = :number",
+		sql.Named("number", number))
+
+	err := row.Scan(&p.Number, &p.Client, &p.Status, &p.Address, &p.CreatedAt)
+	if err != nil {
+		return p, wrapNotFound(number, err)
+	}
+
+	return p, nil
+}
+
+// wrapNotFound оборачивает sql.ErrNoRows в ErrNotFound, чтобы вызывающая
+// сторона (включая транспортный слой) не получала протекающую наружу
+// ошибку database/sql; прочие ошибки возвращаются как есть.
+func wrapNotFound(number int, err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("parcel %d: %w", number, ErrNotFound)
+	}
+	return err
+}
+
+// insertEvent пишет одну строку в parcel_event в рамках переданной транзакции.
+func insertEvent(ctx context.Context, tx *sql.Tx, number int, eventType, oldValue, newValue string) error {
+	_, err := tx.ExecContext(ctx, "INSERT INTO parcel_event (parcel_number, event_type, old_value, new_value, actor, occurred_at) VALUES (:parcel_number, :event_type, :old_value, :new_value, :actor, :occurred_at)",
+		sql.Named("parcel_number", number),
+		sql.Named("event_type", eventType),
+		sql.Named("old_value", oldValue),
+		sql.Named("new_value", newValue),
+		sql.Named("actor", defaultActor),
+		sql.Named("occurred_at", time.Now().UTC().Format(time.RFC3339)))
+
+	return err
+}
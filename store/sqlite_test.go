@@ -0,0 +1,76 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	_ "modernc.org/sqlite" // Импорт драйвера SQLite
+)
+
+// newTestSQLiteStore открывает SQLite в памяти и применяет встроенную копию
+// таблиц из schema.sql (без CHECK на status), чтобы тесты не зависели от
+// файла на диске.
+func newTestSQLiteStore(t *testing.T) SQLiteStore {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	const schema = `
+		CREATE TABLE parcel (
+		    number     INTEGER PRIMARY KEY AUTOINCREMENT,
+		    client     INTEGER NOT NULL,
+		    status     TEXT NOT NULL,
+		    address    TEXT NOT NULL,
+		    created_at TEXT NOT NULL
+		);
+		CREATE TABLE parcel_event (
+		    id            INTEGER PRIMARY KEY AUTOINCREMENT,
+		    parcel_number INTEGER NOT NULL,
+		    event_type    TEXT NOT NULL,
+		    old_value     TEXT NOT NULL,
+		    new_value     TEXT NOT NULL,
+		    actor         TEXT NOT NULL,
+		    occurred_at   TEXT NOT NULL
+		);`
+	if _, err := db.Exec(schema); err != nil {
+		t.Fatalf("applying schema: %v", err)
+	}
+
+	return NewSQLiteStore(db)
+}
+
+func TestSQLiteStoreGetByClientAbortsOnCancelledContext(t *testing.T) {
+	s := newTestSQLiteStore(t)
+	ctx := context.Background()
+
+	const numParcels = 5
+	for i := 0; i < numParcels; i++ {
+		if _, err := s.Add(ctx, Parcel{Client: 1, Status: ParcelStatusRegistered}); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	// Let rows.Next() yield a couple of rows before reporting cancellation,
+	// so the test exercises the mid-loop ctx.Err() check in GetByClient
+	// rather than a cancellation observed before the query even ran.
+	partway := newCountingContext(ctx, 2)
+
+	if _, err := s.GetByClient(partway, 1); err != context.Canceled {
+		t.Fatalf("GetByClient() cancelled mid-scan = %v, want context.Canceled", err)
+	}
+}
+
+func TestSQLiteStoreGetNotFound(t *testing.T) {
+	s := newTestSQLiteStore(t)
+	ctx := context.Background()
+
+	if _, err := s.Get(ctx, 1); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get() of a missing parcel = %v, want ErrNotFound", err)
+	}
+}
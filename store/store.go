@@ -0,0 +1,41 @@
+// Package store содержит модель посылки и интерфейс хранилища.
+package store
+
+import "context"
+
+// Константы для определения статусов посылки.
+const (
+	ParcelStatusRegistered = "registered" // Посылка зарегистрирована.
+	ParcelStatusSent       = "sent"       // Посылка отправлена.
+	ParcelStatusDelivered  = "delivered"  // Посылка доставлена.
+)
+
+// Parcel - структура, представляющая одну посылку.
+type Parcel struct {
+	Number    int    // Уникальный ID посылки.
+	Client    int    // ID клиента.
+	Status    string // Текущий статус.
+	Address   string // Адрес доставки.
+	CreatedAt string // Дата и время регистрации.
+}
+
+// Store - интерфейс хранилища посылок. Позволяет подменять бэкенд
+// (SQLite, in-memory, Postgres, ...) без изменений в слое service.
+// Каждый метод принимает ctx и обязан прервать запрос при его отмене
+// или истечении срока, а не держать соединение до ответа БД.
+type Store interface {
+	Add(ctx context.Context, p Parcel) (int, error)
+	Get(ctx context.Context, number int) (Parcel, error)
+	GetByClient(ctx context.Context, client int) ([]Parcel, error)
+	SetStatus(ctx context.Context, number int, status string) error
+
+	// SetAddress и Delete разрешены только для посылок в статусе 'registered';
+	// для прочих статусов возвращают changed == false и nil error - это не
+	// ошибка вызывающей стороны, а молчаливый no-op, как и раньше. changed
+	// позволяет PublishingMiddleware отличать настоящую мутацию от no-op'а.
+	SetAddress(ctx context.Context, number int, address string) (changed bool, err error)
+	Delete(ctx context.Context, number int) (changed bool, err error)
+
+	// History возвращает журнал событий посылки в хронологическом порядке.
+	History(ctx context.Context, number int) ([]ParcelEvent, error)
+}
@@ -0,0 +1,64 @@
+package store
+
+import "errors"
+
+// ParcelStatusCancelled - терминальный статус, в который посылка может
+// перейти только из 'registered' (например, клиент передумал до отправки).
+const ParcelStatusCancelled = "cancelled"
+
+// ErrInvalidTransition возвращается, когда запрошенный переход статуса не
+// предусмотрен схемой registered -> sent -> delivered (+ registered -> cancelled).
+var ErrInvalidTransition = errors.New("store: invalid status transition")
+
+// ErrTerminalState возвращается при попытке изменить статус посылки,
+// уже находящейся в терминальном состоянии (delivered, cancelled).
+var ErrTerminalState = errors.New("store: parcel is in a terminal state")
+
+// transitions описывает допустимые переходы статуса: для каждого текущего
+// статуса - список статусов, в которые из него можно перейти напрямую.
+// Пустой список означает терминальное состояние.
+var transitions = map[string][]string{
+	ParcelStatusRegistered: {ParcelStatusSent, ParcelStatusCancelled},
+	ParcelStatusSent:       {ParcelStatusDelivered},
+	ParcelStatusDelivered:  {},
+	ParcelStatusCancelled:  {},
+}
+
+// ValidateTransition проверяет, разрешен ли переход статуса from -> to.
+// Вызывается в SetStatus каждой реализации Store, чтобы ни транспортный
+// слой, ни прямой вызов стора не могли протащить произвольную строку в статус.
+func ValidateTransition(from, to string) error {
+	next, ok := transitions[from]
+	if !ok {
+		return ErrInvalidTransition
+	}
+
+	if len(next) == 0 {
+		return ErrTerminalState
+	}
+
+	for _, s := range next {
+		if s == to {
+			return nil
+		}
+	}
+
+	return ErrInvalidTransition
+}
+
+// NextLinearStatus возвращает следующий статус в линейной цепочке
+// registered -> sent -> delivered, либо ErrTerminalState, если текущий
+// статус уже терминальный (delivered, cancelled). Переход в cancelled
+// линейным не считается - он наступает только явным вызовом Cancel.
+func NextLinearStatus(current string) (string, error) {
+	switch current {
+	case ParcelStatusRegistered:
+		return ParcelStatusSent, nil
+	case ParcelStatusSent:
+		return ParcelStatusDelivered, nil
+	case ParcelStatusDelivered, ParcelStatusCancelled:
+		return "", ErrTerminalState
+	default:
+		return "", ErrInvalidTransition
+	}
+}
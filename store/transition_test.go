@@ -0,0 +1,42 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestValidateTransition(t *testing.T) {
+	cases := []struct {
+		from, to string
+		wantErr  error
+	}{
+		{ParcelStatusRegistered, ParcelStatusSent, nil},
+		{ParcelStatusRegistered, ParcelStatusCancelled, nil},
+		{ParcelStatusSent, ParcelStatusDelivered, nil},
+		{ParcelStatusSent, ParcelStatusCancelled, ErrInvalidTransition},
+		{ParcelStatusDelivered, ParcelStatusSent, ErrTerminalState},
+		{ParcelStatusCancelled, ParcelStatusSent, ErrTerminalState},
+	}
+
+	for _, c := range cases {
+		err := ValidateTransition(c.from, c.to)
+		if !errors.Is(err, c.wantErr) {
+			t.Errorf("ValidateTransition(%q, %q) = %v, want %v", c.from, c.to, err, c.wantErr)
+		}
+	}
+}
+
+func TestMemoryStoreSetStatusRejectsInvalidTransition(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+
+	number, err := s.Add(ctx, Parcel{Client: 1, Status: ParcelStatusDelivered})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	if err := s.SetStatus(ctx, number, ParcelStatusSent); !errors.Is(err, ErrTerminalState) {
+		t.Fatalf("SetStatus() on a delivered parcel = %v, want ErrTerminalState", err)
+	}
+}
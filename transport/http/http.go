@@ -0,0 +1,198 @@
+// Package http реализует HTTP/JSON транспорт поверх слоя endpoint.
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-kit/log"
+	"github.com/gorilla/mux"
+
+	"github.com/ClownDUmpling/ParcelTrackerService/endpoint"
+	"github.com/ClownDUmpling/ParcelTrackerService/store"
+)
+
+// errBadRequest оборачивается вокруг ошибок декодирования запроса
+// (невалидный JSON, отсутствующий или нечисловой параметр пути), чтобы
+// statusCodeFor могла отличить вину клиента от внутренней ошибки сервера.
+var errBadRequest = errors.New("http: invalid request")
+
+// NewHTTPHandler собирает http.Handler со всеми маршрутами сервиса посылок.
+// logger используется go-kit сервером для логирования транспортных ошибок,
+// recoverMiddleware оборачивает весь роутер и перехватывает панику обработчиков.
+func NewHTTPHandler(endpoints endpoint.Endpoints, logger log.Logger) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorHandler(transportErrorLogger{logger}),
+		kithttp.ServerErrorEncoder(encodeError),
+	}
+
+	r := mux.NewRouter()
+
+	r.Methods("POST").Path("/parcels").Handler(kithttp.NewServer(
+		endpoints.RegisterEndpoint, decodeRegisterRequest, encodeResponse, opts...))
+
+	r.Methods("GET").Path("/parcels/{number}").Handler(kithttp.NewServer(
+		endpoints.GetEndpoint, decodeGetRequest, encodeResponse, opts...))
+
+	r.Methods("GET").Path("/clients/{id}/parcels").Handler(kithttp.NewServer(
+		endpoints.GetByClientEndpoint, decodeGetByClientRequest, encodeResponse, opts...))
+
+	r.Methods("PATCH").Path("/parcels/{number}/address").Handler(kithttp.NewServer(
+		endpoints.ChangeAddressEndpoint, decodeChangeAddressRequest, encodeResponse, opts...))
+
+	r.Methods("POST").Path("/parcels/{number}/next-status").Handler(kithttp.NewServer(
+		endpoints.NextStatusEndpoint, decodeNextStatusRequest, encodeResponse, opts...))
+
+	r.Methods("POST").Path("/parcels/{number}/cancel").Handler(kithttp.NewServer(
+		endpoints.CancelEndpoint, decodeCancelRequest, encodeResponse, opts...))
+
+	r.Methods("DELETE").Path("/parcels/{number}").Handler(kithttp.NewServer(
+		endpoints.DeleteEndpoint, decodeDeleteRequest, encodeResponse, opts...))
+
+	r.Methods("GET").Path("/parcels/{number}/history").Handler(kithttp.NewServer(
+		endpoints.HistoryEndpoint, decodeHistoryRequest, encodeResponse, opts...))
+
+	return recoverMiddleware(logger)(r)
+}
+
+func decodeRegisterRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req endpoint.RegisterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("http: decoding request body: %w: %w", err, errBadRequest)
+	}
+	return req, nil
+}
+
+func decodeGetRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	number, err := pathInt(r, "number")
+	if err != nil {
+		return nil, err
+	}
+	return endpoint.GetRequest{Number: number}, nil
+}
+
+func decodeGetByClientRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	client, err := pathInt(r, "id")
+	if err != nil {
+		return nil, err
+	}
+	return endpoint.GetByClientRequest{Client: client}, nil
+}
+
+func decodeChangeAddressRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	number, err := pathInt(r, "number")
+	if err != nil {
+		return nil, err
+	}
+
+	var body struct {
+		Address string `json:"address"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("http: decoding request body: %w: %w", err, errBadRequest)
+	}
+
+	return endpoint.ChangeAddressRequest{Number: number, Address: body.Address}, nil
+}
+
+func decodeNextStatusRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	number, err := pathInt(r, "number")
+	if err != nil {
+		return nil, err
+	}
+	return endpoint.NextStatusRequest{Number: number}, nil
+}
+
+func decodeCancelRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	number, err := pathInt(r, "number")
+	if err != nil {
+		return nil, err
+	}
+	return endpoint.CancelRequest{Number: number}, nil
+}
+
+func decodeDeleteRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	number, err := pathInt(r, "number")
+	if err != nil {
+		return nil, err
+	}
+	return endpoint.DeleteRequest{Number: number}, nil
+}
+
+func decodeHistoryRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	number, err := pathInt(r, "number")
+	if err != nil {
+		return nil, err
+	}
+	return endpoint.HistoryRequest{Number: number}, nil
+}
+
+// pathInt читает числовой параметр name из пути запроса, распознанного mux.
+// Обе ошибки - отсутствующий параметр и нечисловое значение - оборачиваются
+// в errBadRequest, чтобы statusCodeFor вернула 400, а не протекла как 500.
+func pathInt(r *http.Request, name string) (int, error) {
+	raw, ok := mux.Vars(r)[name]
+	if !ok {
+		return 0, fmt.Errorf("http: missing path parameter %s: %w", name, errBadRequest)
+	}
+
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("http: invalid path parameter %s=%q: %w: %w", name, raw, err, errBadRequest)
+	}
+
+	return n, nil
+}
+
+// failer реализуют все ответы endpoint-слоя, у которых может быть Err.
+type failer interface {
+	Failed() error
+}
+
+func encodeResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if f, ok := response.(failer); ok && f.Failed() != nil {
+		encodeError(ctx, f.Failed(), w)
+		return nil
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCodeFor(err))
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+// statusCodeFor сопоставляет типизированные ошибки слоя store и декодирования
+// запроса HTTP-статусам: невалидный запрос - 400, отсутствующая посылка - 404,
+// запрещенный переход статуса - 422, попытка изменить терминальную посылку - 409.
+func statusCodeFor(err error) int {
+	switch {
+	case errors.Is(err, errBadRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, store.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, store.ErrInvalidTransition):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, store.ErrTerminalState):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// transportErrorLogger адаптирует go-kit log.Logger к kithttp.ErrorHandler.
+type transportErrorLogger struct {
+	logger log.Logger
+}
+
+func (l transportErrorLogger) Handle(_ context.Context, err error) {
+	l.logger.Log("transport", "HTTP", "err", err)
+}
@@ -0,0 +1,38 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-kit/log"
+)
+
+// recoverMiddleware перехватывает панику в нижележащем обработчике и
+// превращает ее в 500 Internal Server Error вместо падения всего процесса.
+func recoverMiddleware(logger log.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return loggingHandler{logger: logger, next: next}
+	}
+}
+
+// loggingHandler логирует каждый HTTP-запрос (метод, путь, длительность) и
+// восстанавливается после паники внутри next.
+type loggingHandler struct {
+	logger log.Logger
+	next   http.Handler
+}
+
+func (h loggingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	begin := time.Now()
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			h.logger.Log("transport", "HTTP", "panic", rec, "method", r.Method, "path", r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}()
+
+	h.next.ServeHTTP(w, r)
+
+	h.logger.Log("transport", "HTTP", "method", r.Method, "path", r.URL.Path, "took", time.Since(begin))
+}